@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/sts"
+)
+
+// OIDCProvider exchanges a workload identity token (e.g. the token GitHub
+// Actions injects via ACTIONS_ID_TOKEN_REQUEST_TOKEN) for short-lived STS
+// credentials by assuming roleARN, following the same
+// AssumeRoleWithOIDC flow as alibabacloud-oidc-auth.
+type OIDCProvider struct {
+	RoleARN         string
+	OIDCProviderARN string
+	SessionName     string
+
+	// TokenFile holds the path to a file containing the raw OIDC token.
+	// Either TokenFile or Token must be set; TokenFile is re-read on every
+	// Retrieve call so token rotation is picked up automatically.
+	TokenFile string
+	Token     string
+
+	// Region selects the STS endpoint region; defaults to "cn-hangzhou".
+	Region string
+
+	// DurationSeconds bounds the lifetime of the assumed-role session;
+	// defaults to 3600 (STS's own minimum/maximum still apply).
+	DurationSeconds int
+}
+
+func (p OIDCProvider) Retrieve(ctx context.Context) (Credentials, error) {
+	token := p.Token
+	if p.TokenFile != "" {
+		raw, err := os.ReadFile(p.TokenFile)
+		if err != nil {
+			return Credentials{}, fmt.Errorf("azur/auth: read oidc token file %q: %w", p.TokenFile, err)
+		}
+		token = strings.TrimSpace(string(raw))
+	}
+	if token == "" {
+		return Credentials{}, fmt.Errorf("azur/auth: no OIDC token provided (set TokenFile or Token)")
+	}
+	if p.RoleARN == "" || p.OIDCProviderARN == "" {
+		return Credentials{}, fmt.Errorf("azur/auth: RoleARN and OIDCProviderARN are required")
+	}
+
+	region := p.Region
+	if region == "" {
+		region = "cn-hangzhou"
+	}
+	duration := p.DurationSeconds
+	if duration == 0 {
+		duration = 3600
+	}
+	sessionName := p.SessionName
+	if sessionName == "" {
+		sessionName = "azur-sync"
+	}
+
+	client, err := sts.NewClientWithAccessKey(region, "", "")
+	if err != nil {
+		return Credentials{}, fmt.Errorf("azur/auth: build sts client: %w", err)
+	}
+
+	req := sts.CreateAssumeRoleWithOIDCRequest()
+	req.RoleArn = p.RoleARN
+	req.OIDCProviderArn = p.OIDCProviderARN
+	req.OIDCToken = token
+	req.RoleSessionName = sessionName
+	req.DurationSeconds = requests.NewInteger(duration)
+	req.Scheme = "https"
+
+	resp, err := client.AssumeRoleWithOIDC(req)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("azur/auth: AssumeRoleWithOIDC: %w", err)
+	}
+
+	expiration, err := time.Parse(time.RFC3339, resp.Credentials.Expiration)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("azur/auth: parse STS expiration %q: %w", resp.Credentials.Expiration, err)
+	}
+
+	return Credentials{
+		AccessKeyID:     resp.Credentials.AccessKeyId,
+		AccessKeySecret: resp.Credentials.AccessKeySecret,
+		SecurityToken:   resp.Credentials.SecurityToken,
+		Expiration:      expiration.Unix(),
+	}, nil
+}
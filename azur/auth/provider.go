@@ -0,0 +1,28 @@
+// Package auth supplies short-lived and long-lived credentials for Azür
+// Sync so it can run against Alibaba Cloud OSS without requiring a
+// pre-provisioned ossutil config or ambient long-lived AK/SK on disk.
+package auth
+
+import "context"
+
+// Credentials is a single set of OSS access credentials, optionally
+// time-boxed via Expiration. A zero Expiration means the credentials do
+// not expire (e.g. static AK/SK).
+type Credentials struct {
+	AccessKeyID     string
+	AccessKeySecret string
+	SecurityToken   string
+	Expiration      int64 // unix seconds; 0 means no expiry
+}
+
+// Expired reports whether the credentials are past their expiration.
+func (c Credentials) Expired(nowUnix int64) bool {
+	return c.Expiration != 0 && nowUnix >= c.Expiration
+}
+
+// CredentialProvider resolves a set of OSS credentials on demand. Callers
+// should call Retrieve again once Credentials.Expired reports true rather
+// than caching indefinitely.
+type CredentialProvider interface {
+	Retrieve(ctx context.Context) (Credentials, error)
+}
@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RefreshingProvider wraps another CredentialProvider and caches its
+// result until skewSeconds before expiration, so callers can hold onto a
+// single RefreshingProvider for the life of a long-running sync instead of
+// re-deriving credentials on every object upload.
+type RefreshingProvider struct {
+	Source      CredentialProvider
+	SkewSeconds int64
+
+	mu   sync.Mutex
+	last Credentials
+}
+
+func (p *RefreshingProvider) Retrieve(ctx context.Context) (Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	skew := p.SkewSeconds
+	if skew == 0 {
+		skew = 60
+	}
+
+	if p.last.AccessKeyID != "" && !p.last.Expired(time.Now().Unix()+skew) {
+		return p.last, nil
+	}
+
+	creds, err := p.Source.Retrieve(ctx)
+	if err != nil {
+		return Credentials{}, err
+	}
+	p.last = creds
+	return creds, nil
+}
@@ -0,0 +1,28 @@
+package auth
+
+import "os"
+
+// Select picks a CredentialProvider for source ("static", "shared-file",
+// "env", or "oidc"), reading any provider-specific settings from the
+// environment. An empty source defaults to "env".
+func Select(source string) CredentialProvider {
+	if source == "" {
+		source = "env"
+	}
+
+	switch source {
+	case "static":
+		return NewStaticProvider(os.Getenv("OSS_ACCESS_KEY_ID"), os.Getenv("OSS_ACCESS_KEY_SECRET"))
+	case "shared-file":
+		return SharedFileProvider{Path: os.Getenv("AZUR_SHARED_CREDENTIALS_FILE"), Profile: os.Getenv("AZUR_PROFILE")}
+	case "oidc":
+		return &RefreshingProvider{Source: OIDCProvider{
+			RoleARN:         os.Getenv("AZUR_ROLE_ARN"),
+			OIDCProviderARN: os.Getenv("AZUR_OIDC_PROVIDER_ARN"),
+			TokenFile:       os.Getenv("AZUR_OIDC_TOKEN_FILE"),
+			Region:          os.Getenv("AZUR_REGION"),
+		}}
+	default:
+		return EnvProvider{}
+	}
+}
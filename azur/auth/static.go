@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// StaticProvider returns a fixed, never-expiring set of credentials.
+type StaticProvider struct {
+	Creds Credentials
+}
+
+// NewStaticProvider builds a StaticProvider from an access key id/secret pair.
+func NewStaticProvider(accessKeyID, accessKeySecret string) StaticProvider {
+	return StaticProvider{Creds: Credentials{AccessKeyID: accessKeyID, AccessKeySecret: accessKeySecret}}
+}
+
+func (p StaticProvider) Retrieve(ctx context.Context) (Credentials, error) {
+	if p.Creds.AccessKeyID == "" || p.Creds.AccessKeySecret == "" {
+		return Credentials{}, fmt.Errorf("azur/auth: static provider missing access key id or secret")
+	}
+	return p.Creds, nil
+}
+
+// EnvProvider reads credentials from the process environment, following
+// the same variable names as the official Alibaba Cloud CLI.
+type EnvProvider struct{}
+
+func (EnvProvider) Retrieve(ctx context.Context) (Credentials, error) {
+	creds := Credentials{
+		AccessKeyID:     os.Getenv("OSS_ACCESS_KEY_ID"),
+		AccessKeySecret: os.Getenv("OSS_ACCESS_KEY_SECRET"),
+		SecurityToken:   os.Getenv("OSS_SESSION_TOKEN"),
+	}
+	if creds.AccessKeyID == "" || creds.AccessKeySecret == "" {
+		return Credentials{}, fmt.Errorf("azur/auth: OSS_ACCESS_KEY_ID/OSS_ACCESS_KEY_SECRET not set")
+	}
+	return creds, nil
+}
+
+// SharedFileProvider reads credentials from an ini-style shared credentials
+// file of `key = value` lines under `[section]` headers, the format used by
+// ossutil's ~/.ossutilconfig. JSON files such as the Alibaba Cloud CLI's
+// ~/.aliyun/config.json are not parsed.
+type SharedFileProvider struct {
+	// Path defaults to ~/.ossutilconfig when empty.
+	Path string
+	// Profile selects an "[Credentials]"-style section; defaults to the
+	// first section found.
+	Profile string
+}
+
+func (p SharedFileProvider) Retrieve(ctx context.Context) (Credentials, error) {
+	path := p.Path
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return Credentials{}, fmt.Errorf("azur/auth: resolve home dir: %w", err)
+		}
+		path = filepath.Join(home, ".ossutilconfig")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("azur/auth: open shared credentials file %q: %w", path, err)
+	}
+	defer f.Close()
+
+	wantProfile := p.Profile
+	inSection := wantProfile == ""
+	var creds Credentials
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section := strings.Trim(line, "[]")
+			if wantProfile == "" {
+				wantProfile = section
+			}
+			inSection = section == wantProfile
+			continue
+		}
+		if !inSection {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch key {
+		case "accessKeyID", "access_key_id":
+			creds.AccessKeyID = value
+		case "accessKeySecret", "access_key_secret":
+			creds.AccessKeySecret = value
+		case "stsToken", "sts_token":
+			creds.SecurityToken = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Credentials{}, fmt.Errorf("azur/auth: read %q: %w", path, err)
+	}
+	if creds.AccessKeyID == "" || creds.AccessKeySecret == "" {
+		return Credentials{}, fmt.Errorf("azur/auth: no credentials found in %q", path)
+	}
+	return creds, nil
+}
@@ -0,0 +1,58 @@
+// Package config loads Azür Sync's profile configuration from
+// ~/.soulcore/azur.toml, letting a single machine hold named profiles for
+// different buckets, backends, and credential sources.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Profile is one named sync target.
+type Profile struct {
+	Backend          string   `toml:"backend"` // "oss", "s3", "generic-s3"
+	Bucket           string   `toml:"bucket"`
+	Endpoint         string   `toml:"endpoint"`
+	Region           string   `toml:"region"`
+	Prefix           string   `toml:"prefix"`
+	LocalRoot        string   `toml:"local_root"`
+	CredentialSource string   `toml:"credential_source"` // "static", "shared-file", "env", "oidc"
+	Include          []string `toml:"include"`
+	Exclude          []string `toml:"exclude"`
+}
+
+// Config is the parsed contents of azur.toml.
+type Config struct {
+	Profiles map[string]Profile `toml:"profiles"`
+}
+
+// DefaultPath returns ~/.soulcore/azur.toml.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("azur/config: resolve home dir: %w", err)
+	}
+	return filepath.Join(home, ".soulcore", "azur.toml"), nil
+}
+
+// Load parses the config file at path.
+func Load(path string) (*Config, error) {
+	var cfg Config
+	if _, err := toml.DecodeFile(path, &cfg); err != nil {
+		return nil, fmt.Errorf("azur/config: decode %q: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// Profile looks up a named profile, returning an error that names the
+// missing profile.
+func (c *Config) Profile(name string) (Profile, error) {
+	p, ok := c.Profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("azur/config: no such profile %q", name)
+	}
+	return p, nil
+}
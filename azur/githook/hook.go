@@ -0,0 +1,148 @@
+// Package githook installs and removes the git hooks that let a
+// SoulCoreHub checkout act as a source of truth which continuously
+// mirrors itself to cloud storage on every commit.
+package githook
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	beginMarker = "# >>> azur sync hook >>>"
+	endMarker   = "# <<< azur sync hook <<<"
+)
+
+// Names are the hooks Install/Uninstall know how to manage.
+const (
+	PostCommit  = "post-commit"
+	PostReceive = "post-receive"
+)
+
+// scripts maps each supported hook name to the managed block body that
+// drives `azur push` with only the commit's changed paths.
+var scripts = map[string]string{
+	PostCommit: `NEW_SHA=$(git rev-parse HEAD)
+BRANCH=$(git rev-parse --abbrev-ref HEAD)
+PATHS=$(git diff-tree --no-commit-id --name-only -r --root "$NEW_SHA" | paste -sd, -)
+if [ -n "$PATHS" ]; then
+  azur push --paths "$PATHS" --commit "$NEW_SHA" --branch "$BRANCH"
+fi
+`,
+	PostReceive: `while read -r OLD_SHA NEW_SHA REF; do
+  BRANCH=$(echo "$REF" | sed 's#refs/heads/##')
+  PATHS=$(git diff-tree --no-commit-id --name-only -r --root "$NEW_SHA" | paste -sd, -)
+  if [ -n "$PATHS" ]; then
+    azur push --paths "$PATHS" --commit "$NEW_SHA" --branch "$BRANCH"
+  fi
+done
+`,
+}
+
+// Install writes (or rewrites) the managed block for each of hookNames
+// into repoRoot/.git/hooks/<name>, preserving any unmanaged content already
+// in the file. Re-running Install is a no-op on the managed block itself.
+func Install(repoRoot string, hookNames []string) error {
+	for _, name := range hookNames {
+		body, ok := scripts[name]
+		if !ok {
+			return fmt.Errorf("azur/githook: unsupported hook %q", name)
+		}
+		if err := writeManagedHook(repoRoot, name, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Uninstall removes the managed block from each of hookNames, leaving any
+// surrounding unmanaged content intact. A hook file left empty, or holding
+// only the "#!/bin/sh" line, is deleted.
+func Uninstall(repoRoot string, hookNames []string) error {
+	for _, name := range hookNames {
+		if err := removeManagedHook(repoRoot, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hookPath(repoRoot, name string) string {
+	return filepath.Join(repoRoot, ".git", "hooks", name)
+}
+
+func writeManagedHook(repoRoot, name, body string) error {
+	path := hookPath(repoRoot, name)
+
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("azur/githook: read %q: %w", path, err)
+	}
+
+	before, _ := stripManagedBlock(string(existing))
+	managed := beginMarker + "\n" + body + endMarker + "\n"
+
+	content := before
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	if content == "" {
+		content = "#!/bin/sh\n"
+	}
+	content += managed
+
+	if err := os.WriteFile(path, []byte(content), 0o755); err != nil {
+		return fmt.Errorf("azur/githook: write %q: %w", path, err)
+	}
+	return nil
+}
+
+func removeManagedHook(repoRoot, name string) error {
+	path := hookPath(repoRoot, name)
+
+	existing, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("azur/githook: read %q: %w", path, err)
+	}
+
+	before, hadBlock := stripManagedBlock(string(existing))
+	if !hadBlock {
+		return nil
+	}
+
+	if strings.TrimSpace(before) == "" || strings.TrimSpace(before) == "#!/bin/sh" {
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("azur/githook: remove %q: %w", path, err)
+		}
+		return nil
+	}
+
+	if err := os.WriteFile(path, []byte(before), 0o755); err != nil {
+		return fmt.Errorf("azur/githook: write %q: %w", path, err)
+	}
+	return nil
+}
+
+// stripManagedBlock removes a previously-installed managed block (if any)
+// and reports whether one was found, so Install stays idempotent.
+func stripManagedBlock(content string) (rest string, found bool) {
+	start := strings.Index(content, beginMarker)
+	if start == -1 {
+		return content, false
+	}
+	end := strings.Index(content[start:], endMarker)
+	if end == -1 {
+		return content, false
+	}
+	end = start + end + len(endMarker)
+	// Swallow a single trailing newline after the end marker.
+	if end < len(content) && content[end] == '\n' {
+		end++
+	}
+	return content[:start] + content[end:], true
+}
@@ -0,0 +1,94 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
+)
+
+// multipartThreshold is the object size above which Put switches from a
+// single PutObject call to a resumable multipart upload, so a dropped
+// connection partway through a large transfer doesn't restart it from
+// byte zero. ossPartSize is the chunk size used for those uploads.
+const (
+	multipartThreshold = 100 * 1024 * 1024
+	ossPartSize        = 16 * 1024 * 1024
+)
+
+// ossStore adapts an Alibaba Cloud OSS bucket to ObjectStore.
+type ossStore struct {
+	bucket *oss.Bucket
+}
+
+func newOSSStore(cfg Config) (ObjectStore, error) {
+	client, err := oss.New(cfg.Endpoint, cfg.AccessKey, cfg.SecretKey, oss.SecurityToken(cfg.Token))
+	if err != nil {
+		return nil, fmt.Errorf("azur/store: build oss client: %w", err)
+	}
+	bucket, err := client.Bucket(cfg.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("azur/store: open oss bucket %q: %w", cfg.Bucket, err)
+	}
+	return &ossStore{bucket: bucket}, nil
+}
+
+func (s *ossStore) Put(ctx context.Context, key, localPath string, metadata map[string]string) error {
+	var opts []oss.Option
+	for k, v := range metadata {
+		opts = append(opts, oss.Meta(k, v))
+	}
+
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return err
+	}
+	if info.Size() <= multipartThreshold {
+		return s.bucket.PutObjectFromFile(key, localPath, opts...)
+	}
+	return s.bucket.UploadFile(key, localPath, ossPartSize, opts...)
+}
+
+func (s *ossStore) Get(ctx context.Context, key, localPath string) error {
+	return s.bucket.GetObjectToFile(key, localPath)
+}
+
+func (s *ossStore) List(ctx context.Context, prefix string) ([]Object, error) {
+	var out []Object
+	marker := ""
+	for {
+		resp, err := s.bucket.ListObjects(oss.Prefix(prefix), oss.Marker(marker))
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range resp.Objects {
+			out = append(out, Object{
+				Key:          obj.Key,
+				Size:         obj.Size,
+				ETag:         strings.Trim(obj.ETag, `"`),
+				LastModified: obj.LastModified,
+			})
+		}
+		if !resp.IsTruncated {
+			return out, nil
+		}
+		marker = resp.NextMarker
+	}
+}
+
+func (s *ossStore) Stat(ctx context.Context, key string) (Object, error) {
+	header, err := s.bucket.GetObjectMeta(key)
+	if err != nil {
+		return Object{}, err
+	}
+	return Object{
+		Key:  key,
+		ETag: strings.Trim(header.Get("ETag"), `"`),
+	}, nil
+}
+
+func (s *ossStore) Delete(ctx context.Context, key string) error {
+	return s.bucket.DeleteObject(key)
+}
@@ -0,0 +1,139 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// s3Store adapts AWS S3 (and any S3-compatible endpoint, e.g. MinIO or
+// Cloudflare R2) to ObjectStore. generic toggles path-style addressing and
+// a custom endpoint resolver, since non-AWS endpoints don't speak the
+// virtual-hosted-style URLs AWS defaults to.
+type s3Store struct {
+	client   *s3.Client
+	uploader *manager.Uploader
+	bucket   string
+}
+
+func newS3Store(cfg Config, generic bool) (ObjectStore, error) {
+	ctx := context.Background()
+
+	optFns := []func(*awsconfig.LoadOptions) error{
+		awsconfig.WithRegion(cfg.Region),
+	}
+	if cfg.AccessKey != "" {
+		optFns = append(optFns, awsconfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(cfg.AccessKey, cfg.SecretKey, cfg.Token)))
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, optFns...)
+	if err != nil {
+		return nil, fmt.Errorf("azur/store: load aws config: %w", err)
+	}
+
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if generic && cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+			o.UsePathStyle = true
+		}
+	})
+
+	uploader := manager.NewUploader(client)
+
+	return &s3Store{client: client, uploader: uploader, bucket: cfg.Bucket}, nil
+}
+
+// Put uploads localPath, resuming cleanly even on a connection drop: the
+// manager.Uploader above splits anything larger than its part size (5 MiB)
+// into a multipart upload, so Put never has to restart a large object from
+// byte zero. Small objects still go up as a single PutObject.
+func (s *s3Store) Put(ctx context.Context, key, localPath string, metadata map[string]string) error {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = s.uploader.Upload(ctx, &s3.PutObjectInput{
+		Bucket:   aws.String(s.bucket),
+		Key:      aws.String(key),
+		Body:     f,
+		Metadata: metadata,
+	})
+	return err
+}
+
+func (s *s3Store) Get(ctx context.Context, key, localPath string) error {
+	resp, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	f, err := os.Create(localPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.ReadFrom(resp.Body)
+	return err
+}
+
+func (s *s3Store) List(ctx context.Context, prefix string) ([]Object, error) {
+	var out []Object
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range page.Contents {
+			out = append(out, Object{
+				Key:          aws.ToString(obj.Key),
+				Size:         aws.ToInt64(obj.Size),
+				ETag:         strings.Trim(aws.ToString(obj.ETag), `"`),
+				LastModified: aws.ToTime(obj.LastModified),
+			})
+		}
+	}
+	return out, nil
+}
+
+func (s *s3Store) Stat(ctx context.Context, key string) (Object, error) {
+	resp, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return Object{}, err
+	}
+	return Object{
+		Key:          key,
+		Size:         aws.ToInt64(resp.ContentLength),
+		ETag:         strings.Trim(aws.ToString(resp.ETag), `"`),
+		LastModified: aws.ToTime(resp.LastModified),
+	}, nil
+}
+
+func (s *s3Store) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	return err
+}
@@ -0,0 +1,58 @@
+// Package store defines the backend-agnostic object storage interface
+// that the sync engine runs against, plus a factory for the backends
+// Azür Sync ships with: Alibaba Cloud OSS, AWS S3, and any generic
+// S3-compatible endpoint (MinIO, Cloudflare R2, ...).
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Object describes a single stored object's metadata.
+type Object struct {
+	Key          string
+	Size         int64
+	ETag         string
+	LastModified time.Time
+}
+
+// ObjectStore is the minimal surface the sync engine needs from a backend.
+// Every backend (OSS, S3, generic S3-compatible) implements this the same
+// way so the engine itself never branches on backend type.
+type ObjectStore interface {
+	// Put uploads localPath to key. metadata is attached as user metadata
+	// (OSS's x-oss-meta-* headers, S3's x-amz-meta-*); nil means none.
+	Put(ctx context.Context, key, localPath string, metadata map[string]string) error
+	Get(ctx context.Context, key, localPath string) error
+	List(ctx context.Context, prefix string) ([]Object, error)
+	Stat(ctx context.Context, key string) (Object, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Config carries whatever a backend needs to construct a client. Not every
+// field applies to every backend; New ignores the ones its backend doesn't use.
+type Config struct {
+	Backend   string // "oss", "s3", "generic-s3"
+	Bucket    string
+	Endpoint  string
+	Region    string
+	AccessKey string
+	SecretKey string
+	Token     string
+}
+
+// New builds an ObjectStore for cfg.Backend.
+func New(cfg Config) (ObjectStore, error) {
+	switch cfg.Backend {
+	case "oss", "":
+		return newOSSStore(cfg)
+	case "s3":
+		return newS3Store(cfg, false)
+	case "generic-s3":
+		return newS3Store(cfg, true)
+	default:
+		return nil, fmt.Errorf("azur/store: unknown backend %q", cfg.Backend)
+	}
+}
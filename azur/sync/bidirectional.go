@@ -0,0 +1,324 @@
+package sync
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"soulcorehub/azur/store"
+)
+
+// ConflictStrategy selects how Bidirectional resolves a path that changed
+// on both sides since the last sync.
+type ConflictStrategy string
+
+const (
+	ConflictLocalWins  ConflictStrategy = "local-wins"
+	ConflictRemoteWins ConflictStrategy = "remote-wins"
+	ConflictKeepBoth   ConflictStrategy = "keep-both"
+	ConflictPrompt     ConflictStrategy = "prompt"
+)
+
+// ManifestFileName is the default manifest path, relative to localRoot.
+const ManifestFileName = ".azur-manifest.json"
+
+// conflictSuffixMarker is the infix keep-both uses when it saves the
+// remote side of a conflict alongside the local one. Like the manifest
+// itself, files carrying it are sync bookkeeping, not tree content, so
+// Bidirectional must not treat them as a newly-added local file on the
+// next run (that would push them back up and pollute the bucket forever).
+const conflictSuffixMarker = ".conflict-"
+
+// isConflictCopy reports whether relPath is a keep-both artifact.
+func isConflictCopy(relPath string) bool {
+	return strings.Contains(filepath.Base(relPath), conflictSuffixMarker)
+}
+
+// pathState classifies a single relative path against the last synced manifest.
+type pathState int
+
+const (
+	stateUnchanged pathState = iota
+	stateLocalChange
+	stateRemoteChange
+	stateConflict
+	stateDeletedLocally
+	stateDeletedRemotely
+)
+
+// Bidirectional reconciles localRoot against the store under prefix using
+// the manifest at localRoot/.azur-manifest.json to tell which side changed
+// since the last run. Non-conflicting changes apply automatically;
+// conflicting ones are resolved per strategy. When deleteEnabled is false,
+// deletions are never propagated even if the manifest would otherwise
+// confirm them.
+func (s *Syncer) Bidirectional(ctx context.Context, localRoot, prefix string, strategy ConflictStrategy, deleteEnabled bool) (Stats, error) {
+	var stats Stats
+
+	localRoot, err := s.resolveLocalRoot(localRoot)
+	if err != nil {
+		return stats, err
+	}
+
+	manifestPath := filepath.Join(localRoot, ManifestFileName)
+	manifest, err := LoadManifest(manifestPath)
+	if err != nil {
+		return stats, err
+	}
+
+	remote, err := s.listRemote(ctx, prefix)
+	if err != nil {
+		return stats, fmt.Errorf("azur/sync: list remote objects: %w", err)
+	}
+
+	localFiles, err := s.walkLocal(localRoot)
+	if err != nil {
+		return stats, fmt.Errorf("azur/sync: walk %q: %w", localRoot, err)
+	}
+	local := make(map[string]bool, len(localFiles))
+	for _, f := range localFiles {
+		if f == ManifestFileName || isConflictCopy(f) {
+			continue
+		}
+		local[f] = true
+	}
+	stats.Scanned = len(local)
+
+	paths := make(map[string]bool)
+	for p := range local {
+		paths[p] = true
+	}
+	for key := range remote {
+		paths[strings.TrimPrefix(key, trimPrefixSlash(prefix))] = true
+	}
+	for p := range manifest.Entries {
+		paths[p] = true
+	}
+
+	for relPath := range paths {
+		if !s.included(relPath) {
+			continue
+		}
+		if err := s.reconcileOne(ctx, localRoot, prefix, relPath, manifest, local[relPath], remote, strategy, deleteEnabled, &stats); err != nil {
+			stats.Failed++
+		}
+	}
+
+	if s.DryRun {
+		return stats, nil
+	}
+	if err := manifest.Save(manifestPath); err != nil {
+		return stats, err
+	}
+	return stats, nil
+}
+
+func (s *Syncer) reconcileOne(ctx context.Context, localRoot, prefix, relPath string, manifest *Manifest, localExists bool, remote map[string]store.Object, strategy ConflictStrategy, deleteEnabled bool, stats *Stats) error {
+	absPath := filepath.Join(localRoot, filepath.FromSlash(relPath))
+	key := joinKey(prefix, relPath)
+
+	remoteObj, remoteExists := remote[key]
+	manifestEntry, manifestExists := manifest.Entries[relPath]
+
+	var localSum string
+	var localSize int64
+	if localExists {
+		var err error
+		localSum, localSize, err = hashFile(absPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	localChanged := localExists && (!manifestExists || localSum != manifestEntry.SHA256)
+	remoteChanged := remoteExists && (!manifestExists || remoteObj.ETag != manifestEntry.RemoteETag)
+
+	// On the first run there is no manifest entry to compare against, so a
+	// path present on both sides would otherwise always look changed on
+	// both sides -- a conflict. Compare local content against the remote
+	// ETag directly to tell a genuine conflict from a file that's simply
+	// never been recorded yet.
+	if !manifestExists && localExists && remoteExists {
+		if localMD5, _, err := hashFileMD5(absPath); err == nil && remoteObj.ETag != "" && strings.EqualFold(localMD5, remoteObj.ETag) {
+			localChanged = false
+			remoteChanged = false
+		}
+	}
+
+	state := classify(localExists, remoteExists, manifestExists, localChanged, remoteChanged)
+
+	switch state {
+	case stateUnchanged:
+		if !manifestExists && localExists {
+			manifest.Entries[relPath] = ManifestEntry{
+				SHA256:            localSum,
+				Size:              localSize,
+				ModTime:           now(),
+				RemoteETag:        remoteObj.ETag,
+				LastSyncedVersion: remoteObj.ETag,
+			}
+		}
+		stats.Skipped++
+
+	case stateLocalChange:
+		if err := s.pushAndRecord(ctx, absPath, key, relPath, localSum, localSize, manifest, stats); err != nil {
+			return err
+		}
+
+	case stateRemoteChange:
+		if err := s.pullAndRecord(ctx, absPath, key, relPath, remoteObj, manifest, stats); err != nil {
+			return err
+		}
+
+	case stateDeletedLocally:
+		if deleteEnabled {
+			if !s.DryRun {
+				if err := s.Store.Delete(ctx, key); err != nil {
+					return err
+				}
+			}
+			delete(manifest.Entries, relPath)
+			stats.Deleted++
+		}
+
+	case stateDeletedRemotely:
+		if deleteEnabled {
+			if !s.DryRun {
+				if err := os.Remove(absPath); err != nil && !os.IsNotExist(err) {
+					return err
+				}
+			}
+			delete(manifest.Entries, relPath)
+			stats.Deleted++
+		}
+
+	case stateConflict:
+		stats.Conflicts++
+		return s.resolveConflict(ctx, localRoot, absPath, key, relPath, localSum, localSize, remoteObj, manifest, strategy, stats)
+	}
+
+	return nil
+}
+
+func classify(localExists, remoteExists, manifestExists, localChanged, remoteChanged bool) pathState {
+	switch {
+	case localExists && remoteExists:
+		switch {
+		case localChanged && remoteChanged:
+			return stateConflict
+		case localChanged:
+			return stateLocalChange
+		case remoteChanged:
+			return stateRemoteChange
+		default:
+			return stateUnchanged
+		}
+	case localExists && !remoteExists:
+		if manifestExists {
+			return stateDeletedRemotely
+		}
+		return stateLocalChange
+	case !localExists && remoteExists:
+		if manifestExists {
+			return stateDeletedLocally
+		}
+		return stateRemoteChange
+	default:
+		return stateUnchanged
+	}
+}
+
+func (s *Syncer) pushAndRecord(ctx context.Context, absPath, key, relPath, localSum string, localSize int64, manifest *Manifest, stats *Stats) error {
+	if !s.DryRun {
+		if err := s.Store.Put(ctx, key, absPath, s.Metadata); err != nil {
+			return err
+		}
+	}
+	obj, err := s.Store.Stat(ctx, key)
+	if err != nil {
+		obj = store.Object{ETag: localSum}
+	}
+	manifest.Entries[relPath] = ManifestEntry{
+		SHA256:            localSum,
+		Size:              localSize,
+		ModTime:           now(),
+		RemoteETag:        obj.ETag,
+		LastSyncedVersion: obj.ETag,
+	}
+	stats.Uploaded++
+	stats.BytesSent += localSize
+	return nil
+}
+
+func (s *Syncer) pullAndRecord(ctx context.Context, absPath, key, relPath string, remoteObj store.Object, manifest *Manifest, stats *Stats) error {
+	if !s.DryRun {
+		if err := os.MkdirAll(filepath.Dir(absPath), 0o755); err != nil {
+			return err
+		}
+		if err := s.Store.Get(ctx, key, absPath); err != nil {
+			return err
+		}
+	}
+	sum, size, err := hashFile(absPath)
+	if err != nil {
+		sum, size = remoteObj.ETag, remoteObj.Size
+	}
+	manifest.Entries[relPath] = ManifestEntry{
+		SHA256:            sum,
+		Size:              size,
+		ModTime:           now(),
+		RemoteETag:        remoteObj.ETag,
+		LastSyncedVersion: remoteObj.ETag,
+	}
+	stats.Uploaded++
+	stats.BytesSent += size
+	return nil
+}
+
+func (s *Syncer) resolveConflict(ctx context.Context, localRoot, absPath, key, relPath, localSum string, localSize int64, remoteObj store.Object, manifest *Manifest, strategy ConflictStrategy, stats *Stats) error {
+	switch strategy {
+	case ConflictRemoteWins:
+		return s.pullAndRecord(ctx, absPath, key, relPath, remoteObj, manifest, stats)
+
+	case ConflictKeepBoth:
+		conflictPath := fmt.Sprintf("%s%s%d", absPath, conflictSuffixMarker, now().Unix())
+		if !s.DryRun {
+			if err := s.Store.Get(ctx, key, conflictPath); err != nil {
+				return err
+			}
+		}
+		return s.pushAndRecord(ctx, absPath, key, relPath, localSum, localSize, manifest, stats)
+
+	case ConflictPrompt:
+		if keepLocal(relPath) {
+			return s.pushAndRecord(ctx, absPath, key, relPath, localSum, localSize, manifest, stats)
+		}
+		return s.pullAndRecord(ctx, absPath, key, relPath, remoteObj, manifest, stats)
+
+	case ConflictLocalWins, "":
+		return s.pushAndRecord(ctx, absPath, key, relPath, localSum, localSize, manifest, stats)
+
+	default:
+		return fmt.Errorf("azur/sync: unknown conflict strategy %q", strategy)
+	}
+}
+
+// keepLocal asks the operator, on stdin, whether the local copy of relPath
+// should win a conflict.
+func keepLocal(relPath string) bool {
+	fmt.Printf("conflict on %s: keep local version? [y/N] ", relPath)
+	reader := bufio.NewReader(os.Stdin)
+	answer, _ := reader.ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+func trimPrefixSlash(prefix string) string {
+	if prefix == "" {
+		return ""
+	}
+	return strings.TrimSuffix(prefix, "/") + "/"
+}
@@ -0,0 +1,58 @@
+package sync
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ManifestEntry records what a path looked like, locally and remotely, the
+// last time it was successfully synced in either direction.
+type ManifestEntry struct {
+	SHA256            string    `json:"sha256"`
+	Size              int64     `json:"size"`
+	ModTime           time.Time `json:"mtime"`
+	RemoteETag        string    `json:"remote_etag"`
+	LastSyncedVersion string    `json:"last_synced_version"`
+}
+
+// Manifest is the content-addressed record a bidirectional sync keeps
+// between runs, normally persisted as .azur-manifest.json at the root of
+// the synced tree.
+type Manifest struct {
+	Entries map[string]ManifestEntry `json:"entries"`
+}
+
+// LoadManifest reads the manifest at path. A missing file is not an
+// error: it yields an empty manifest, as on a fresh checkout.
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Manifest{Entries: map[string]ManifestEntry{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("azur/sync: read manifest %q: %w", path, err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("azur/sync: parse manifest %q: %w", path, err)
+	}
+	if m.Entries == nil {
+		m.Entries = map[string]ManifestEntry{}
+	}
+	return &m, nil
+}
+
+// Save writes the manifest to path as indented JSON.
+func (m *Manifest) Save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("azur/sync: encode manifest: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("azur/sync: write manifest %q: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package sync
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+)
+
+// ResolveRoot turns a user-supplied local root (which may start with a
+// literal "~") into a canonical absolute path. Passing "~/SoulCoreHub/" to
+// exec.Command used to upload nothing on many setups, since only a shell
+// expands "~" — ossutil received the four literal characters. Resolving it
+// ourselves means the same sync works whether it's invoked from a shell,
+// a cron job, or a git hook with a minimal environment.
+func ResolveRoot(raw string) (string, error) {
+	expanded, err := expandHome(raw)
+	if err != nil {
+		return "", err
+	}
+
+	abs, err := filepath.Abs(expanded)
+	if err != nil {
+		return "", fmt.Errorf("azur/sync: resolve absolute path for %q: %w", raw, err)
+	}
+
+	// EvalSymlinks requires the path to exist; a root that hasn't been
+	// created yet (e.g. before a first pull) is not an error here.
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+
+	return abs, nil
+}
+
+func expandHome(raw string) (string, error) {
+	if raw != "~" && !strings.HasPrefix(raw, "~/") {
+		return raw, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		u, uerr := user.Current()
+		if uerr != nil {
+			return "", fmt.Errorf("azur/sync: resolve home directory: %w", err)
+		}
+		home = u.HomeDir
+	}
+
+	if raw == "~" {
+		return home, nil
+	}
+	return filepath.Join(home, raw[2:]), nil
+}
+
+// relUnderRoot computes p's path relative to root and rejects any result
+// that escapes root via "..", which filepath.Walk can otherwise produce
+// when root contains a symlink pointing outside itself.
+func relUnderRoot(root, p string) (string, error) {
+	rel, err := filepath.Rel(root, p)
+	if err != nil {
+		return "", err
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("azur/sync: path %q escapes root %q", p, root)
+	}
+	return rel, nil
+}
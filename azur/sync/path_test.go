@@ -0,0 +1,35 @@
+package sync
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveRootExpandsTilde(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	viaTilde, err := ResolveRoot("~/SoulCoreHub/")
+	if err != nil {
+		t.Fatalf("ResolveRoot(~/SoulCoreHub/): %v", err)
+	}
+
+	viaHomeEnv, err := ResolveRoot(filepath.Join(home, "SoulCoreHub"))
+	if err != nil {
+		t.Fatalf("ResolveRoot($HOME/SoulCoreHub): %v", err)
+	}
+
+	if viaTilde != viaHomeEnv {
+		t.Fatalf("expected identical paths, got %q and %q", viaTilde, viaHomeEnv)
+	}
+}
+
+func TestRelUnderRootRejectsEscape(t *testing.T) {
+	root := "/home/soulcore/tree"
+	if _, err := relUnderRoot(root, "/home/soulcore/other/file.txt"); err == nil {
+		t.Fatal("expected an error for a path outside root")
+	}
+	if _, err := relUnderRoot(root, "/home/soulcore/tree/sub/file.txt"); err != nil {
+		t.Fatalf("unexpected error for a path inside root: %v", err)
+	}
+}
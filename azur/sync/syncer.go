@@ -0,0 +1,502 @@
+// Package sync implements the sync engine behind Azür Sync: it walks a
+// local directory tree, diffs it against the remote object listing of
+// whichever store.ObjectStore backend is configured, and pushes or pulls
+// whatever changed. Pushes run on a bounded worker pool with retry/backoff;
+// pulls download one object at a time with a single attempt each.
+package sync
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"soulcorehub/azur/store"
+)
+
+// Stats summarizes the outcome of a single Push or Pull run.
+type Stats struct {
+	Scanned   int   `json:"scanned"`
+	Uploaded  int   `json:"uploaded"`
+	Skipped   int   `json:"skipped"`
+	Deleted   int   `json:"deleted"`
+	Failed    int   `json:"failed"`
+	Conflicts int   `json:"conflicts"`
+	BytesSent int64 `json:"bytes_sent"`
+}
+
+// Change describes one path that Diff found to differ between local and remote.
+type Change struct {
+	Key    string `json:"key"`
+	Status string `json:"status"` // "local-only", "remote-only", "modified"
+}
+
+// transferEvent is emitted as a single JSON line per object so failures are
+// actionable instead of a single "sync failed" message.
+type transferEvent struct {
+	Time     time.Time `json:"time"`
+	Key      string    `json:"key"`
+	Action   string    `json:"action"` // "upload", "skip", "dry-run", "delete", "dry-run-delete", "error"
+	Bytes    int64     `json:"bytes,omitempty"`
+	Err      string    `json:"error,omitempty"`
+	Attempts int       `json:"attempts,omitempty"`
+}
+
+// Syncer walks a local directory tree and mirrors it against an
+// store.ObjectStore. It is backend-agnostic: the same engine drives OSS,
+// S3, and any generic S3-compatible endpoint.
+type Syncer struct {
+	Store store.ObjectStore
+
+	// Include/Exclude are shell glob patterns (filepath.Match syntax),
+	// tested against every path-segment suffix of each path relative to
+	// localRoot rather than the whole path, so a pattern scopes a tree
+	// regardless of depth: "*.log" matches "sub/app.log" (via its "app.log"
+	// suffix) and "node_modules/*" matches "a/b/node_modules/x.js" (via its
+	// "node_modules/x.js" suffix). Exclude wins ties.
+	Include []string
+	Exclude []string
+
+	// DryRun, when true, computes and logs the diff but performs no
+	// network writes.
+	DryRun bool
+
+	// Concurrency bounds the number of simultaneous transfers. Defaults to 8.
+	Concurrency int
+
+	// MaxAttempts bounds retries per object on transient failure. Defaults to 3.
+	MaxAttempts int
+
+	// Logger receives one JSON line per object transfer, preceded by one
+	// plain-text line naming the resolved local root. Defaults to os.Stderr.
+	Logger io.Writer
+
+	// Metadata is attached as user metadata to every object this Syncer
+	// uploads (e.g. the triggering commit SHA and branch). Nil means none.
+	Metadata map[string]string
+}
+
+// NewSyncer builds a Syncer backed by s with sane defaults.
+func NewSyncer(s store.ObjectStore) *Syncer {
+	return &Syncer{
+		Store:       s,
+		Concurrency: 8,
+		MaxAttempts: 3,
+		Logger:      os.Stderr,
+	}
+}
+
+// Push walks localRoot and uploads every included, changed file to the
+// store under prefix. It returns Stats describing what happened even when
+// err is non-nil, so callers can report partial progress.
+func (s *Syncer) Push(ctx context.Context, localRoot, prefix string) (Stats, error) {
+	localRoot, err := s.resolveLocalRoot(localRoot)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	files, err := s.walkLocal(localRoot)
+	if err != nil {
+		return Stats{}, fmt.Errorf("azur/sync: walk %q: %w", localRoot, err)
+	}
+	return s.pushFiles(ctx, localRoot, prefix, files)
+}
+
+// PushPaths uploads only relPaths (paths relative to localRoot), skipping
+// the full tree walk. This is what the post-commit hook uses so a commit
+// that touches a handful of files doesn't trigger a full-tree scan.
+func (s *Syncer) PushPaths(ctx context.Context, localRoot, prefix string, relPaths []string) (Stats, error) {
+	localRoot, err := s.resolveLocalRoot(localRoot)
+	if err != nil {
+		return Stats{}, err
+	}
+	return s.pushFiles(ctx, localRoot, prefix, relPaths)
+}
+
+// resolveLocalRoot canonicalizes localRoot (expanding a leading "~") and
+// logs the result so a sync run started from cron or a git hook, where
+// there is no shell to expand "~", is still auditable.
+func (s *Syncer) resolveLocalRoot(localRoot string) (string, error) {
+	resolved, err := ResolveRoot(localRoot)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(s.loggerOrStderr(), "azur/sync: resolved local root %q -> %q\n", localRoot, resolved)
+	return resolved, nil
+}
+
+func (s *Syncer) loggerOrStderr() io.Writer {
+	if s.Logger != nil {
+		return s.Logger
+	}
+	return os.Stderr
+}
+
+func (s *Syncer) pushFiles(ctx context.Context, localRoot, prefix string, files []string) (Stats, error) {
+	var stats Stats
+
+	remote, err := s.listRemote(ctx, prefix)
+	if err != nil {
+		return stats, fmt.Errorf("azur/sync: list remote objects: %w", err)
+	}
+	stats.Scanned = len(files)
+
+	type job struct {
+		absPath string
+		key     string
+	}
+	jobs := make(chan job)
+	var (
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	concurrency := s.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				n, deleted, err := s.pushOne(ctx, j.absPath, j.key, remote[j.key])
+				mu.Lock()
+				switch {
+				case err != nil:
+					stats.Failed++
+					if firstErr == nil {
+						firstErr = err
+					}
+				case deleted:
+					stats.Deleted++
+				case n < 0:
+					stats.Skipped++
+				default:
+					stats.Uploaded++
+					stats.BytesSent += n
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, relPath := range files {
+		if !s.included(relPath) {
+			continue
+		}
+		absPath := filepath.Join(localRoot, relPath)
+		key := joinKey(prefix, relPath)
+		select {
+		case jobs <- job{absPath: absPath, key: key}:
+		case <-ctx.Done():
+			close(jobs)
+			wg.Wait()
+			return stats, ctx.Err()
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return stats, firstErr
+}
+
+// Pull downloads every remote object under prefix into localRoot,
+// overwriting local files whose content hash differs from the remote ETag.
+func (s *Syncer) Pull(ctx context.Context, localRoot, prefix string) (Stats, error) {
+	var stats Stats
+
+	localRoot, err := s.resolveLocalRoot(localRoot)
+	if err != nil {
+		return stats, err
+	}
+
+	remote, err := s.listRemote(ctx, prefix)
+	if err != nil {
+		return stats, fmt.Errorf("azur/sync: list remote objects: %w", err)
+	}
+	stats.Scanned = len(remote)
+
+	for key, obj := range remote {
+		rel := strings.TrimPrefix(key, strings.TrimSuffix(prefix, "/")+"/")
+		if !s.included(rel) {
+			continue
+		}
+		absPath := filepath.Join(localRoot, filepath.FromSlash(rel))
+
+		if sum, _, err := hashFileMD5(absPath); err == nil && strings.EqualFold(sum, obj.ETag) {
+			s.log(transferEvent{Time: now(), Key: key, Action: "skip", Bytes: obj.Size})
+			stats.Skipped++
+			continue
+		}
+
+		if s.DryRun {
+			s.log(transferEvent{Time: now(), Key: key, Action: "dry-run", Bytes: obj.Size})
+			stats.Skipped++
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(absPath), 0o755); err != nil {
+			stats.Failed++
+			s.log(transferEvent{Time: now(), Key: key, Action: "error", Err: err.Error()})
+			continue
+		}
+		if err := s.Store.Get(ctx, key, absPath); err != nil {
+			stats.Failed++
+			s.log(transferEvent{Time: now(), Key: key, Action: "error", Err: err.Error()})
+			continue
+		}
+		s.log(transferEvent{Time: now(), Key: key, Action: "upload", Bytes: obj.Size})
+		stats.Uploaded++
+		stats.BytesSent += obj.Size
+	}
+
+	return stats, nil
+}
+
+// Diff reports every path that differs between localRoot and the store
+// under prefix, without transferring anything.
+func (s *Syncer) Diff(ctx context.Context, localRoot, prefix string) ([]Change, error) {
+	localRoot, err := s.resolveLocalRoot(localRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := s.listRemote(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("azur/sync: list remote objects: %w", err)
+	}
+	files, err := s.walkLocal(localRoot)
+	if err != nil {
+		return nil, fmt.Errorf("azur/sync: walk %q: %w", localRoot, err)
+	}
+
+	var changes []Change
+	seen := make(map[string]bool, len(files))
+	for _, relPath := range files {
+		if !s.included(relPath) {
+			continue
+		}
+		key := joinKey(prefix, relPath)
+		seen[key] = true
+
+		obj, ok := remote[key]
+		if !ok {
+			changes = append(changes, Change{Key: key, Status: "local-only"})
+			continue
+		}
+		sum, _, err := hashFileMD5(filepath.Join(localRoot, relPath))
+		if err == nil && !strings.EqualFold(sum, obj.ETag) {
+			changes = append(changes, Change{Key: key, Status: "modified"})
+		}
+	}
+	for key := range remote {
+		if !seen[key] {
+			changes = append(changes, Change{Key: key, Status: "remote-only"})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes, nil
+}
+
+// pushOne uploads a single file if its content hash differs from the
+// remote object's metadata. It returns the number of bytes sent, or -1 if
+// nothing was uploaded because the file was already up to date or DryRun
+// is set. A path that no
+// longer exists on disk (e.g. one named by a PushPaths caller for a commit
+// that deleted or renamed it) is treated as a deletion and removed from
+// the store instead of failing the run.
+func (s *Syncer) pushOne(ctx context.Context, absPath, key string, remoteObj store.Object) (bytesSent int64, deleted bool, err error) {
+	if _, statErr := os.Stat(absPath); os.IsNotExist(statErr) {
+		if s.DryRun {
+			s.log(transferEvent{Time: now(), Key: key, Action: "dry-run-delete"})
+			return 0, true, nil
+		}
+		if err := s.Store.Delete(ctx, key); err != nil {
+			s.log(transferEvent{Time: now(), Key: key, Action: "error", Err: err.Error()})
+			return 0, false, fmt.Errorf("delete %q: %w", key, err)
+		}
+		s.log(transferEvent{Time: now(), Key: key, Action: "delete"})
+		return 0, true, nil
+	}
+
+	sum, size, err := hashFileMD5(absPath)
+	if err != nil {
+		s.log(transferEvent{Time: now(), Key: key, Action: "error", Err: err.Error()})
+		return 0, false, fmt.Errorf("hash %q: %w", absPath, err)
+	}
+
+	if remoteObj.ETag != "" && strings.EqualFold(remoteObj.ETag, sum) {
+		s.log(transferEvent{Time: now(), Key: key, Action: "skip", Bytes: size})
+		return -1, false, nil
+	}
+
+	if s.DryRun {
+		s.log(transferEvent{Time: now(), Key: key, Action: "dry-run", Bytes: size})
+		return -1, false, nil
+	}
+
+	var lastErr error
+	maxAttempts := s.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		select {
+		case <-ctx.Done():
+			return 0, false, ctx.Err()
+		default:
+		}
+
+		putErr := s.Store.Put(ctx, key, absPath, s.Metadata)
+		if putErr == nil {
+			s.log(transferEvent{Time: now(), Key: key, Action: "upload", Bytes: size, Attempts: attempt})
+			return size, false, nil
+		}
+		lastErr = putErr
+		time.Sleep(backoff(attempt))
+	}
+
+	s.log(transferEvent{Time: now(), Key: key, Action: "error", Err: lastErr.Error(), Attempts: maxAttempts})
+	return 0, false, fmt.Errorf("put %q: %w", key, lastErr)
+}
+
+func (s *Syncer) listRemote(ctx context.Context, prefix string) (map[string]store.Object, error) {
+	objs, err := s.Store.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]store.Object, len(objs))
+	for _, obj := range objs {
+		out[obj.Key] = obj
+	}
+	return out, nil
+}
+
+func (s *Syncer) walkLocal(root string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := relUnderRoot(root, p)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
+
+func (s *Syncer) included(relPath string) bool {
+	for _, pattern := range s.Exclude {
+		if matchGlob(pattern, relPath) {
+			return false
+		}
+	}
+	if len(s.Include) == 0 {
+		return true
+	}
+	for _, pattern := range s.Include {
+		if matchGlob(pattern, relPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob reports whether pattern (filepath.Match syntax) matches
+// relPath or any suffix of relPath's "/"-separated segments. filepath.Match
+// alone never lets "*" cross a "/", so a pattern like "*.log" would
+// otherwise only ever match a file directly at localRoot's top level.
+func matchGlob(pattern, relPath string) bool {
+	segments := strings.Split(relPath, "/")
+	for i := range segments {
+		candidate := strings.Join(segments[i:], "/")
+		if matched, _ := filepath.Match(pattern, candidate); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Syncer) log(evt transferEvent) {
+	w := s.Logger
+	if w == nil {
+		w = os.Stderr
+	}
+	enc := json.NewEncoder(w)
+	_ = enc.Encode(evt)
+}
+
+// hashFile returns the SHA-256 of p's content, used to detect local change
+// against the sync manifest (see ManifestEntry.SHA256). It is never
+// comparable to a remote ETag, which is an MD5-family digest — use
+// hashFileMD5 for that.
+func hashFile(p string) (sum string, size int64, err error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
+
+// hashFileMD5 returns the MD5 of p's content, matching the ETag OSS and S3
+// assign a single-part object on upload, so it can be compared directly
+// against store.Object.ETag to decide whether a transfer is needed.
+func hashFileMD5(p string) (sum string, size int64, err error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	h := md5.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
+
+func joinKey(prefix, relPath string) string {
+	if prefix == "" {
+		return relPath
+	}
+	return strings.TrimSuffix(prefix, "/") + "/" + relPath
+}
+
+func backoff(attempt int) time.Duration {
+	d := time.Duration(attempt*attempt) * 200 * time.Millisecond
+	if d > 5*time.Second {
+		d = 5 * time.Second
+	}
+	return d
+}
+
+// now is a var so tests can stub out wall-clock time.
+var now = time.Now
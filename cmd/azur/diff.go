@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newDiffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff",
+		Short: "Show paths that differ between the local tree and the backend",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			syncer, profile, err := newSyncer(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			changes, err := syncer.Diff(cmd.Context(), localRootOf(profile), profile.Prefix)
+			if err != nil {
+				return fmt.Errorf("diff: %w", err)
+			}
+			if len(changes) == 0 {
+				fmt.Println("up to date")
+				return nil
+			}
+			for _, c := range changes {
+				fmt.Printf("%-12s %s\n", c.Status, c.Key)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"soulcorehub/azur/githook"
+)
+
+func newHookCmd() *cobra.Command {
+	var withPostReceive bool
+
+	hookCmd := &cobra.Command{
+		Use:   "hook",
+		Short: "Manage git hooks that trigger cloud sync on commit",
+	}
+
+	install := &cobra.Command{
+		Use:   "install",
+		Short: "Install a post-commit (and optional post-receive) hook",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			names := []string{githook.PostCommit}
+			if withPostReceive {
+				names = append(names, githook.PostReceive)
+			}
+			repoRoot, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			if err := githook.Install(repoRoot, names); err != nil {
+				return err
+			}
+			fmt.Println("✅ installed:", names)
+			return nil
+		},
+	}
+	install.Flags().BoolVar(&withPostReceive, "post-receive", false, "also install the post-receive hook")
+
+	uninstall := &cobra.Command{
+		Use:   "uninstall",
+		Short: "Remove the managed post-commit/post-receive hooks",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repoRoot, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			if err := githook.Uninstall(repoRoot, []string{githook.PostCommit, githook.PostReceive}); err != nil {
+				return err
+			}
+			fmt.Println("✅ uninstalled")
+			return nil
+		},
+	}
+
+	hookCmd.AddCommand(install, uninstall)
+	return hookCmd
+}
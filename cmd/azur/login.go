@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"soulcorehub/azur/auth"
+)
+
+// newLoginCmd implements `azur login`: it assumes a RAM role via OIDC and
+// prints the resulting STS session as shell export statements, so callers
+// run `eval "$(azur login ...)"` before push/pull/diff.
+func newLoginCmd() *cobra.Command {
+	var (
+		tokenFile       string
+		roleARN         string
+		oidcProviderARN string
+		region          string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Assume a RAM role via OIDC and print an STS session as shell exports",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			provider := auth.OIDCProvider{
+				TokenFile:       tokenFile,
+				RoleARN:         roleARN,
+				OIDCProviderARN: oidcProviderARN,
+				Region:          region,
+			}
+			creds, err := provider.Retrieve(cmd.Context())
+			if err != nil {
+				return err
+			}
+			fmt.Printf("export OSS_ACCESS_KEY_ID=%s\n", creds.AccessKeyID)
+			fmt.Printf("export OSS_ACCESS_KEY_SECRET=%s\n", creds.AccessKeySecret)
+			fmt.Printf("export OSS_SESSION_TOKEN=%s\n", creds.SecurityToken)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&tokenFile, "oidc-token-file", "", "path to the OIDC token to exchange")
+	cmd.Flags().StringVar(&roleARN, "role-arn", "", "ARN of the RAM role to assume")
+	cmd.Flags().StringVar(&oidcProviderARN, "oidc-provider-arn", "", "ARN of the OIDC identity provider")
+	cmd.Flags().StringVar(&region, "region", "cn-hangzhou", "STS region")
+	return cmd
+}
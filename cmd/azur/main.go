@@ -0,0 +1,105 @@
+// Command azur is the Azür Sync CLI: it pushes, pulls, and diffs a local
+// directory tree against a configured object storage backend (Alibaba
+// Cloud OSS, AWS S3, or any generic S3-compatible endpoint).
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"soulcorehub/azur/auth"
+	"soulcorehub/azur/config"
+	"soulcorehub/azur/store"
+	"soulcorehub/azur/sync"
+)
+
+var (
+	profileName     string
+	backendOverride string
+	configPath      string
+)
+
+func main() {
+	root := &cobra.Command{
+		Use:   "azur",
+		Short: "Azür Sync: mirror ~/SoulCoreHub/ to cloud object storage",
+	}
+	root.PersistentFlags().StringVar(&profileName, "profile", "default", "named profile from azur.toml")
+	root.PersistentFlags().StringVar(&backendOverride, "backend", "", "override the profile's backend: oss, s3, generic-s3")
+	root.PersistentFlags().StringVar(&configPath, "config", "", "path to azur.toml (defaults to ~/.soulcore/azur.toml)")
+
+	root.AddCommand(newPushCmd(), newPullCmd(), newDiffCmd(), newStatusCmd(), newSyncCmd(), newLoginCmd(), newHookCmd())
+
+	if err := root.Execute(); err != nil {
+		fmt.Println("❌", err)
+		os.Exit(1)
+	}
+}
+
+// resolveProfile loads the selected profile from config and applies any
+// --backend override.
+func resolveProfile() (config.Profile, error) {
+	path := configPath
+	if path == "" {
+		var err error
+		path, err = config.DefaultPath()
+		if err != nil {
+			return config.Profile{}, err
+		}
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		return config.Profile{}, err
+	}
+	profile, err := cfg.Profile(profileName)
+	if err != nil {
+		return config.Profile{}, err
+	}
+	if backendOverride != "" {
+		profile.Backend = backendOverride
+	}
+	return profile, nil
+}
+
+// newSyncer builds a Syncer for the selected profile, including resolving
+// credentials and constructing the right ObjectStore backend.
+func newSyncer(ctx context.Context) (*sync.Syncer, config.Profile, error) {
+	profile, err := resolveProfile()
+	if err != nil {
+		return nil, config.Profile{}, err
+	}
+
+	creds, err := auth.Select(profile.CredentialSource).Retrieve(ctx)
+	if err != nil {
+		return nil, config.Profile{}, fmt.Errorf("resolve credentials: %w", err)
+	}
+
+	objStore, err := store.New(store.Config{
+		Backend:   profile.Backend,
+		Bucket:    profile.Bucket,
+		Endpoint:  profile.Endpoint,
+		Region:    profile.Region,
+		AccessKey: creds.AccessKeyID,
+		SecretKey: creds.AccessKeySecret,
+		Token:     creds.SecurityToken,
+	})
+	if err != nil {
+		return nil, config.Profile{}, fmt.Errorf("build object store: %w", err)
+	}
+
+	syncer := sync.NewSyncer(objStore)
+	syncer.Include = profile.Include
+	syncer.Exclude = profile.Exclude
+	return syncer, profile, nil
+}
+
+func localRootOf(profile config.Profile) string {
+	if profile.LocalRoot != "" {
+		return profile.LocalRoot
+	}
+	return "~/SoulCoreHub/"
+}
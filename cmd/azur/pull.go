@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newPullCmd() *cobra.Command {
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "pull",
+		Short: "Download remote changes from the configured backend",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			syncer, profile, err := newSyncer(cmd.Context())
+			if err != nil {
+				return err
+			}
+			syncer.DryRun = dryRun
+
+			stats, err := syncer.Pull(cmd.Context(), localRootOf(profile), profile.Prefix)
+			if err != nil {
+				return fmt.Errorf("pull: %w", err)
+			}
+			fmt.Printf("✅ pulled %d, skipped %d, failed %d, %d bytes received\n",
+				stats.Uploaded, stats.Skipped, stats.Failed, stats.BytesSent)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the diff without downloading")
+	return cmd
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"soulcorehub/azur/sync"
+)
+
+func newPushCmd() *cobra.Command {
+	var (
+		dryRun bool
+		paths  string
+		commit string
+		branch string
+	)
+	cmd := &cobra.Command{
+		Use:   "push",
+		Short: "Upload local changes to the configured backend",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			syncer, profile, err := newSyncer(cmd.Context())
+			if err != nil {
+				return err
+			}
+			syncer.DryRun = dryRun
+			if commit != "" || branch != "" {
+				syncer.Metadata = map[string]string{"commit": commit, "branch": branch}
+			}
+
+			localRoot, prefix := localRootOf(profile), profile.Prefix
+
+			var stats sync.Stats
+			if paths != "" {
+				stats, err = syncer.PushPaths(cmd.Context(), localRoot, prefix, strings.Split(paths, ","))
+			} else {
+				stats, err = syncer.Push(cmd.Context(), localRoot, prefix)
+			}
+			if err != nil {
+				return fmt.Errorf("push: %w", err)
+			}
+
+			fmt.Printf("✅ pushed %d, skipped %d, deleted %d, failed %d, %d bytes sent\n",
+				stats.Uploaded, stats.Skipped, stats.Deleted, stats.Failed, stats.BytesSent)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the diff without uploading")
+	cmd.Flags().StringVar(&paths, "paths", "", "comma-separated list of paths (relative to local root) to push, instead of walking the whole tree")
+	cmd.Flags().StringVar(&commit, "commit", "", "commit SHA to tag uploaded objects with (x-oss-meta-commit)")
+	cmd.Flags().StringVar(&branch, "branch", "", "branch name to tag uploaded objects with (x-oss-meta-branch)")
+	return cmd
+}
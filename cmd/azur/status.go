@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Summarize how out of sync the local tree and backend are",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			syncer, profile, err := newSyncer(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			changes, err := syncer.Diff(cmd.Context(), localRootOf(profile), profile.Prefix)
+			if err != nil {
+				return fmt.Errorf("status: %w", err)
+			}
+
+			var localOnly, remoteOnly, modified int
+			for _, c := range changes {
+				switch c.Status {
+				case "local-only":
+					localOnly++
+				case "remote-only":
+					remoteOnly++
+				case "modified":
+					modified++
+				}
+			}
+
+			fmt.Printf("profile %q (%s): %d local-only, %d remote-only, %d modified\n",
+				profileName, profile.Backend, localOnly, remoteOnly, modified)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"soulcorehub/azur/sync"
+)
+
+func newSyncCmd() *cobra.Command {
+	var (
+		conflict string
+		deleteOn bool
+		dryRun   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Reconcile local and remote changes in both directions",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			strategy := sync.ConflictStrategy(conflict)
+			switch strategy {
+			case sync.ConflictLocalWins, sync.ConflictRemoteWins, sync.ConflictKeepBoth, sync.ConflictPrompt:
+			default:
+				return fmt.Errorf("--conflict must be one of local-wins, remote-wins, keep-both, prompt")
+			}
+
+			syncer, profile, err := newSyncer(cmd.Context())
+			if err != nil {
+				return err
+			}
+			syncer.DryRun = dryRun
+
+			stats, err := syncer.Bidirectional(cmd.Context(), localRootOf(profile), profile.Prefix, strategy, deleteOn)
+			if err != nil {
+				return fmt.Errorf("sync: %w", err)
+			}
+			fmt.Printf("✅ synced %d, skipped %d, conflicts %d, failed %d, %d bytes moved\n",
+				stats.Uploaded, stats.Skipped, stats.Conflicts, stats.Failed, stats.BytesSent)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&conflict, "conflict", string(sync.ConflictLocalWins), "conflict strategy: local-wins, remote-wins, keep-both, prompt")
+	cmd.Flags().BoolVar(&deleteOn, "delete", false, "propagate deletions confirmed by the manifest")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "compute the reconciliation without transferring anything")
+	return cmd
+}